@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ReelTubeVR/reeltube-cli/internal/ratelimit"
+)
+
+// sharedBucket is the single rate limiter every part upload in this process
+// draws from, so --max-bandwidth bounds aggregate throughput across however
+// many files upload-batch has in flight at once, not just one file's worth
+// of workers. It's (re)built once per invocation by setMaxBandwidthFromFlag.
+var sharedBucket *ratelimit.Bucket
+
+// setMaxBandwidthFromFlag parses the --max-bandwidth flag, if present on
+// cmd, into the package-level maxBandwidthBytes and (re)builds the shared
+// rate limiter bucket every part upload in this process draws from.
+func setMaxBandwidthFromFlag(cmd *cobra.Command) error {
+	raw, err := cmd.Flags().GetString("max-bandwidth")
+	if err != nil {
+		return nil
+	}
+	bytesPerSec, err := parseBandwidth(raw)
+	if err != nil {
+		return err
+	}
+	maxBandwidthBytes = bytesPerSec
+	sharedBucket = ratelimit.NewBucket(maxBandwidthBytes)
+	return nil
+}
+
+// parseBandwidth parses a human-friendly bandwidth limit like "5MB",
+// "500KB", or "2GB" into bytes per second. An empty string means "no
+// limit". Units are binary (1KB = 1024 bytes).
+func parseBandwidth(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	upper := strings.ToUpper(s)
+	multiplier := int64(1)
+	numPart := upper
+
+	switch {
+	case strings.HasSuffix(upper, "GB"):
+		multiplier = 1024 * 1024 * 1024
+		numPart = strings.TrimSuffix(upper, "GB")
+	case strings.HasSuffix(upper, "MB"):
+		multiplier = 1024 * 1024
+		numPart = strings.TrimSuffix(upper, "MB")
+	case strings.HasSuffix(upper, "KB"):
+		multiplier = 1024
+		numPart = strings.TrimSuffix(upper, "KB")
+	case strings.HasSuffix(upper, "B"):
+		numPart = strings.TrimSuffix(upper, "B")
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(numPart), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth %q: %w", s, err)
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
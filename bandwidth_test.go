@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestParseBandwidth(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"100", 100, false},
+		{"100B", 100, false},
+		{"5KB", 5 * 1024, false},
+		{"5MB", 5 * 1024 * 1024, false},
+		{"2GB", 2 * 1024 * 1024 * 1024, false},
+		{"1.5MB", int64(1.5 * 1024 * 1024), false},
+		{"  5MB  ", 5 * 1024 * 1024, false},
+		{"5mb", 5 * 1024 * 1024, false},
+		{"not-a-number", 0, true},
+		{"MB", 0, true},
+	}
+	for _, tc := range cases {
+		got, err := parseBandwidth(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseBandwidth(%q) = %d, <nil>, want an error", tc.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseBandwidth(%q) returned unexpected error: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseBandwidth(%q) = %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+const checkpointSuffix = ".reeltube-upload.json"
+
+// UploadCheckpoint is the on-disk state of an in-progress multipart upload.
+// It is written to a sidecar file next to the source file so an interrupted
+// upload can be resumed without re-uploading parts that already succeeded.
+type UploadCheckpoint struct {
+	FilePath      string       `json:"file_path"`
+	FileName      string       `json:"file_name"`
+	FileSHA256    string       `json:"file_sha256"`
+	MediaUploadID string       `json:"media_upload_id"`
+	UploadID      string       `json:"upload_id"`
+	PartSize      int          `json:"part_size"`
+	NumParts      int          `json:"num_parts"`
+	PresignedURLs []string     `json:"presigned_urls"`
+	Parts         map[int]Part `json:"parts"` // completed parts, keyed by part index (0-based)
+}
+
+// checkpointPath returns the sidecar checkpoint path for a given source file.
+func checkpointPath(filePath string) string {
+	return filePath + checkpointSuffix
+}
+
+func saveCheckpoint(path string, cp *UploadCheckpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+func loadCheckpoint(path string) (*UploadCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var cp UploadCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	if cp.Parts == nil {
+		cp.Parts = make(map[int]Part)
+	}
+	return &cp, nil
+}
+
+func deleteCheckpoint(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
+
+// fileSHA256 computes the SHA-256 digest of the file at path, hex-encoded.
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for hashing: %w", err)
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -166,11 +166,26 @@ type CreateMediaUploadResponse struct {
 	MediaUpload   MediaUpload `json:"media_upload"`
 }
 
-func (c *Client) CreateMediaUpload(fileName string, fileSize int) (*CreateMediaUploadResponse, error) {
+// MediaMetadata holds optional per-upload overrides — tags and visibility —
+// typically sourced from an upload-batch manifest. The zero value applies
+// no overrides.
+type MediaMetadata struct {
+	Tags       []string
+	Visibility string
+}
+
+func (c *Client) CreateMediaUpload(fileName string, fileSize int, sha256Hex string, meta MediaMetadata) (*CreateMediaUploadResponse, error) {
 	var data CreateMediaUploadResponse
 	body := map[string]interface{}{
 		"filename": fileName,
 		"size":     fileSize,
+		"sha256":   sha256Hex,
+	}
+	if len(meta.Tags) > 0 {
+		body["tags"] = meta.Tags
+	}
+	if meta.Visibility != "" {
+		body["visibility"] = meta.Visibility
 	}
 	_, err := c.Post("/api/v0/media_uploads", body, &data)
 	if err != nil {
@@ -179,6 +194,47 @@ func (c *Client) CreateMediaUpload(fileName string, fileSize int) (*CreateMediaU
 	return &data, nil
 }
 
+type LookupMediaByHashResponse struct {
+	Exists  bool   `json:"exists"`
+	MediaID string `json:"media_id"`
+}
+
+// LookupMediaByHash checks whether a blob matching sha256Hex has already
+// been uploaded, so the caller can skip re-uploading an identical file.
+func (c *Client) LookupMediaByHash(sha256Hex string) (*LookupMediaByHashResponse, error) {
+	var data LookupMediaByHashResponse
+	_, err := c.Get(fmt.Sprintf("/api/v0/media/lookup?sha256=%s", sha256Hex), nil, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+type createMediaFromHashResponse struct {
+	MediaUpload MediaUpload `json:"media_upload"`
+}
+
+// CreateMediaFromHash creates a new logical media entry that references an
+// existing blob by its sha256 digest, without requiring any part PUTs.
+func (c *Client) CreateMediaFromHash(fileName, sha256Hex string, meta MediaMetadata) (*MediaUpload, error) {
+	var data createMediaFromHashResponse
+	body := map[string]interface{}{
+		"filename": fileName,
+		"sha256":   sha256Hex,
+	}
+	if len(meta.Tags) > 0 {
+		body["tags"] = meta.Tags
+	}
+	if meta.Visibility != "" {
+		body["visibility"] = meta.Visibility
+	}
+	_, err := c.Post("/api/v0/media_uploads/from_hash", body, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data.MediaUpload, nil
+}
+
 type CompleteUploadRequest struct {
 	MediaUploadID string `json:"id"`
 	UploadID      string `json:"upload_id"`
@@ -202,3 +258,55 @@ func (c *Client) CompleteMultipartUpload(mediaUploadID, uploadID string, parts [
 	}
 	return nil
 }
+
+// CreateStreamingMediaUpload reserves a media upload without a known total
+// size, for sources (e.g. a live-fetched remote stream) where the size isn't
+// known until the reader is exhausted. The server allocates a fixed part
+// size and presigned URLs are requested one at a time via
+// GetPresignedPartURL as the client discovers it needs more parts.
+func (c *Client) CreateStreamingMediaUpload(fileName string) (*CreateMediaUploadResponse, error) {
+	var data CreateMediaUploadResponse
+	body := map[string]interface{}{
+		"filename":  fileName,
+		"streaming": true,
+	}
+	_, err := c.Post("/api/v0/media_uploads", body, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+type presignedPartURLResponse struct {
+	URL string `json:"url"`
+}
+
+// GetPresignedPartURL requests a presigned PUT URL for a single part of a
+// streaming media upload that was created without an upfront part count.
+func (c *Client) GetPresignedPartURL(mediaUploadID, uploadID string, partNumber int) (string, error) {
+	var data presignedPartURLResponse
+	path := fmt.Sprintf("/api/v0/media_uploads/%s/parts/%d?upload_id=%s", mediaUploadID, partNumber, uploadID)
+	_, err := c.Get(path, nil, &data)
+	if err != nil {
+		return "", err
+	}
+	return data.URL, nil
+}
+
+type MediaUploadStatusResponse struct {
+	MediaUpload   MediaUpload `json:"media_upload"`
+	Status        string      `json:"status"`
+	PartsReceived []int       `json:"parts_received"`
+}
+
+// GetMediaUploadStatus polls the server for the current state of a media
+// upload, so a caller that reserved a slot elsewhere (e.g. a different CI
+// runner) can check progress without holding the upload state locally.
+func (c *Client) GetMediaUploadStatus(mediaUploadID string) (*MediaUploadStatusResponse, error) {
+	var data MediaUploadStatusResponse
+	_, err := c.Get(fmt.Sprintf("/api/v0/media_uploads/%s", mediaUploadID), nil, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
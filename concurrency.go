@@ -0,0 +1,115 @@
+package main
+
+import "sync"
+
+const (
+	minAdaptiveConcurrency = 1
+	maxAdaptiveConcurrency = 32
+	// sustainedErrorThreshold is how many consecutive part failures in a
+	// row count as "sustained" errors, halving the concurrency limit.
+	sustainedErrorThreshold = 3
+)
+
+func clampConcurrency(n int) int {
+	if n < minAdaptiveConcurrency {
+		return minAdaptiveConcurrency
+	}
+	if n > maxAdaptiveConcurrency {
+		return maxAdaptiveConcurrency
+	}
+	return n
+}
+
+// adaptiveLimiter is a resizable semaphore used to scale part-upload
+// concurrency up on success and down (halved) on sustained errors when
+// --adaptive is set. With adaptive disabled, its limit is simply never
+// adjusted after construction.
+type adaptiveLimiter struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	active int
+
+	consecutiveErrors int
+}
+
+func newAdaptiveLimiter(initial int) *adaptiveLimiter {
+	l := &adaptiveLimiter{limit: clampConcurrency(initial)}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+func (l *adaptiveLimiter) acquire() {
+	l.mu.Lock()
+	for l.active >= l.limit {
+		l.cond.Wait()
+	}
+	l.active++
+	l.mu.Unlock()
+}
+
+func (l *adaptiveLimiter) release() {
+	l.mu.Lock()
+	l.active--
+	l.cond.Broadcast()
+	l.mu.Unlock()
+}
+
+// reportSuccess grows the limit by one and resets the error streak.
+func (l *adaptiveLimiter) reportSuccess() {
+	l.mu.Lock()
+	l.consecutiveErrors = 0
+	if l.limit < maxAdaptiveConcurrency {
+		l.limit++
+		l.cond.Broadcast()
+	}
+	l.mu.Unlock()
+}
+
+// reportError halves the limit once sustainedErrorThreshold failures have
+// been observed in a row.
+func (l *adaptiveLimiter) reportError() {
+	l.mu.Lock()
+	l.consecutiveErrors++
+	if l.consecutiveErrors >= sustainedErrorThreshold {
+		l.limit = clampConcurrency(l.limit / 2)
+		l.consecutiveErrors = 0
+	}
+	l.mu.Unlock()
+}
+
+func (l *adaptiveLimiter) current() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// throughputTracker keeps an exponential moving average of upload
+// throughput, updated as each part completes.
+type throughputTracker struct {
+	mu  sync.Mutex
+	ema float64 // bytes/sec
+}
+
+const throughputEMAAlpha = 0.3
+
+func (t *throughputTracker) update(bytes int, elapsed float64) {
+	if elapsed <= 0 {
+		return
+	}
+	instant := float64(bytes) / elapsed
+
+	t.mu.Lock()
+	if t.ema == 0 {
+		t.ema = instant
+	} else {
+		t.ema = throughputEMAAlpha*instant + (1-throughputEMAAlpha)*t.ema
+	}
+	t.mu.Unlock()
+}
+
+func (t *throughputTracker) value() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.ema
+}
@@ -0,0 +1,118 @@
+package main
+
+import "testing"
+
+func TestClampConcurrency(t *testing.T) {
+	cases := []struct {
+		in   int
+		want int
+	}{
+		{0, minAdaptiveConcurrency},
+		{-5, minAdaptiveConcurrency},
+		{1, 1},
+		{16, 16},
+		{maxAdaptiveConcurrency, maxAdaptiveConcurrency},
+		{1000, maxAdaptiveConcurrency},
+	}
+	for _, tc := range cases {
+		if got := clampConcurrency(tc.in); got != tc.want {
+			t.Errorf("clampConcurrency(%d) = %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestAdaptiveLimiterAcquireRelease(t *testing.T) {
+	l := newAdaptiveLimiter(2)
+	l.acquire()
+	l.acquire()
+	if got := l.current(); got != 2 {
+		t.Fatalf("current() = %d, want 2", got)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		l.acquire() // should block until a release happens
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("acquire() did not block while limiter was at capacity")
+	default:
+	}
+
+	l.release()
+	<-done // now unblocked
+}
+
+func TestAdaptiveLimiterReportSuccessGrowsLimit(t *testing.T) {
+	l := newAdaptiveLimiter(1)
+	l.reportSuccess()
+	if got := l.current(); got != 2 {
+		t.Errorf("current() after reportSuccess = %d, want 2", got)
+	}
+}
+
+func TestAdaptiveLimiterReportSuccessDoesNotExceedMax(t *testing.T) {
+	l := newAdaptiveLimiter(maxAdaptiveConcurrency)
+	l.reportSuccess()
+	if got := l.current(); got != maxAdaptiveConcurrency {
+		t.Errorf("current() = %d, want capped at %d", got, maxAdaptiveConcurrency)
+	}
+}
+
+func TestAdaptiveLimiterReportErrorHalvesAfterThreshold(t *testing.T) {
+	l := newAdaptiveLimiter(16)
+	for i := 0; i < sustainedErrorThreshold-1; i++ {
+		l.reportError()
+		if got := l.current(); got != 16 {
+			t.Errorf("current() after %d errors = %d, want unchanged at 16", i+1, got)
+		}
+	}
+	l.reportError() // the sustainedErrorThreshold-th consecutive error
+	if got := l.current(); got != 8 {
+		t.Errorf("current() after %d consecutive errors = %d, want 8", sustainedErrorThreshold, got)
+	}
+}
+
+func TestAdaptiveLimiterReportSuccessResetsErrorStreak(t *testing.T) {
+	l := newAdaptiveLimiter(16)
+	l.reportError()
+	l.reportError()
+	l.reportSuccess() // should reset the streak
+	l.reportError()
+	l.reportError()
+	if got := l.current(); got != 17 {
+		t.Errorf("current() = %d, want 17 (grew once, never halved)", got)
+	}
+}
+
+func TestThroughputTrackerValueStartsZero(t *testing.T) {
+	tr := &throughputTracker{}
+	if got := tr.value(); got != 0 {
+		t.Errorf("value() on a fresh tracker = %f, want 0", got)
+	}
+}
+
+func TestThroughputTrackerUpdateIgnoresNonPositiveElapsed(t *testing.T) {
+	tr := &throughputTracker{}
+	tr.update(1024, 0)
+	tr.update(1024, -1)
+	if got := tr.value(); got != 0 {
+		t.Errorf("value() after non-positive elapsed updates = %f, want 0", got)
+	}
+}
+
+func TestThroughputTrackerUpdateSeedsThenSmooths(t *testing.T) {
+	tr := &throughputTracker{}
+	tr.update(1000, 1) // first sample seeds the EMA directly
+	if got := tr.value(); got != 1000 {
+		t.Fatalf("value() after first update = %f, want 1000", got)
+	}
+
+	tr.update(2000, 1) // second sample should move the EMA toward, not to, 2000
+	got := tr.value()
+	if got <= 1000 || got >= 2000 {
+		t.Errorf("value() after second update = %f, want strictly between 1000 and 2000", got)
+	}
+}
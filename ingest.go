@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/kkdai/youtube/v2"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(ingestCmd)
+	ingestCmd.Flags().String("name", "", "Override the upload name (defaults to the video title or URL filename)")
+	ingestCmd.Flags().String("quality", "", "Preferred YouTube format quality, e.g. \"hd1080\" or \"medium\"")
+	ingestCmd.Flags().String("format", "", "Preferred YouTube format MIME type, e.g. \"video/mp4\"")
+	ingestCmd.Flags().BoolVar(&streamUpload, "stream", false, "Upload while fetching instead of buffering the whole stream to disk first")
+}
+
+var ingestCmd = &cobra.Command{
+	Use:   "ingest <url>",
+	Short: "Fetch remote media (YouTube or a direct URL) and upload it to ReelTube",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sourceURL := args[0]
+		uploadName, _ := cmd.Flags().GetString("name")
+		quality, _ := cmd.Flags().GetString("quality")
+		format, _ := cmd.Flags().GetString("format")
+
+		reader, sizeHint, fileName, err := openIngestSource(sourceURL, quality, format)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		defer reader.Close()
+
+		if uploadName != "" {
+			fileName = uploadName
+		}
+
+		fmt.Printf("Ingesting %s as %q\n", sourceURL, fileName)
+
+		if err := multipartUploadFromReader(reader, sizeHint, fileName); err != nil {
+			fmt.Println("Error uploading file:", err)
+			os.Exit(1)
+		}
+		fmt.Println("File uploaded successfully")
+	},
+}
+
+// openIngestSource resolves sourceURL to a readable stream. YouTube URLs are
+// resolved to the best matching format via the youtube package; any other
+// http(s) URL is fetched directly as a fallback.
+func openIngestSource(sourceURL, quality, format string) (io.ReadCloser, int64, string, error) {
+	if isYouTubeURL(sourceURL) {
+		return openYouTubeStream(sourceURL, quality, format)
+	}
+	return openHTTPStream(sourceURL)
+}
+
+func isYouTubeURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	return host == "youtube.com" || strings.HasSuffix(host, ".youtube.com") || host == "youtu.be"
+}
+
+func openYouTubeStream(sourceURL, quality, format string) (io.ReadCloser, int64, string, error) {
+	client := youtube.Client{}
+
+	video, err := client.GetVideo(sourceURL)
+	if err != nil {
+		var playabilityErr *youtube.ErrPlayabiltyStatus
+		if errors.As(err, &playabilityErr) {
+			return nil, 0, "", fmt.Errorf("video is not playable (%s: %s) -- it may be age-restricted or region-blocked", playabilityErr.Status, playabilityErr.Reason)
+		}
+		return nil, 0, "", fmt.Errorf("failed to resolve YouTube video: %w", err)
+	}
+
+	formats := video.Formats
+	if quality != "" {
+		if filtered := formats.Quality(quality); len(filtered) > 0 {
+			formats = filtered
+		}
+	}
+	if format != "" {
+		if filtered := formats.Type(format); len(filtered) > 0 {
+			formats = filtered
+		}
+	}
+	if len(formats) == 0 {
+		return nil, 0, "", fmt.Errorf("no format matching quality=%q format=%q found for video %q", quality, format, video.Title)
+	}
+	formats.Sort()
+	chosen := &formats[0]
+
+	stream, contentLength, err := client.GetStreamContext(context.Background(), video, chosen)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to open YouTube stream: %w", err)
+	}
+
+	return stream, contentLength, video.Title, nil
+}
+
+func openHTTPStream(rawURL string) (io.ReadCloser, int64, string, error) {
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, 0, "", fmt.Errorf("failed to fetch %s: received non-200 status code %d", rawURL, resp.StatusCode)
+	}
+
+	fileName := fileNameFromURL(rawURL)
+	return resp.Body, resp.ContentLength, fileName, nil
+}
+
+func fileNameFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "download"
+	}
+	base := path.Base(parsed.Path)
+	if base == "" || base == "." || base == "/" {
+		return "download"
+	}
+	return base
+}
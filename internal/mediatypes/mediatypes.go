@@ -0,0 +1,18 @@
+// Package mediatypes defines the file extensions and MIME types ReelTube
+// accepts for video and photo uploads, shared by every command that walks
+// local files looking for media to upload.
+package mediatypes
+
+// AllowedExtensions is the set of file extensions ReelTube will accept for
+// upload, matched case-insensitively.
+var AllowedExtensions = map[string]bool{
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true,
+	".mp4": true, ".mov": true, ".avi": true, ".mkv": true,
+}
+
+// AllowedMIMEs is the set of MIME types ReelTube will accept for upload,
+// checked against the extension mapping and, failing that, a content sniff.
+var AllowedMIMEs = map[string]bool{
+	"image/jpeg": true, "image/png": true, "image/gif": true,
+	"video/mp4": true, "video/quicktime": true, "video/x-msvideo": true, "video/x-matroska": true,
+}
@@ -0,0 +1,71 @@
+// Package ratelimit provides a simple shared token-bucket limiter used to
+// cap aggregate upload bandwidth across concurrent workers.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Bucket is a token-bucket rate limiter. A single Bucket is meant to be
+// shared across every worker goroutine that draws from the same bandwidth
+// budget; each caller waits for len(chunk) tokens before sending its data.
+// A nil *Bucket (or one created with a non-positive rate) never blocks,
+// which keeps callers simple when no limit is configured.
+type Bucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	capacity   float64
+	refillRate float64 // tokens (bytes) per second
+	last       time.Time
+}
+
+// NewBucket creates a Bucket that allows up to bytesPerSecond bytes through
+// per second, bursting up to one second's worth of tokens. A bytesPerSecond
+// of 0 or less disables throttling entirely.
+func NewBucket(bytesPerSecond int64) *Bucket {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	rate := float64(bytesPerSecond)
+	return &Bucket{
+		tokens:     rate,
+		capacity:   rate,
+		refillRate: rate,
+		last:       time.Now(),
+	}
+}
+
+// Wait blocks until n tokens (bytes) have been debited from the bucket,
+// refilling it based on elapsed wall-clock time since the last call. n may
+// exceed capacity (a single part can easily be larger than one second's
+// worth of bytes at the configured rate) -- tokens are allowed to go
+// negative in that case, and the wait is sized off the resulting deficit, so
+// a request never blocks forever waiting for a ceiling it can't reach.
+func (b *Bucket) Wait(n int) {
+	if b == nil {
+		return
+	}
+
+	need := float64(n)
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.refillRate
+	b.last = now
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+
+	var wait time.Duration
+	if b.tokens < need {
+		deficit := need - b.tokens
+		wait = time.Duration(deficit / b.refillRate * float64(time.Second))
+	}
+	b.tokens -= need
+	b.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
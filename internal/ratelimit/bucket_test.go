@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewBucketUnlimited(t *testing.T) {
+	for _, rate := range []int64{0, -1, -1000} {
+		if b := NewBucket(rate); b != nil {
+			t.Errorf("NewBucket(%d) = %v, want nil", rate, b)
+		}
+	}
+}
+
+func TestNilBucketWaitNeverBlocks(t *testing.T) {
+	var b *Bucket
+	start := time.Now()
+	b.Wait(1 << 30) // a size that would never fit any real budget
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("nil Bucket.Wait blocked for %v, want ~instant", elapsed)
+	}
+}
+
+func TestBucketWaitWithinCapacityDoesNotBlock(t *testing.T) {
+	b := NewBucket(1024 * 1024) // 1MB/s, starts full
+	start := time.Now()
+	b.Wait(512 * 1024) // half a second's budget, already available
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Wait within capacity blocked for %v, want ~instant", elapsed)
+	}
+}
+
+// TestBucketWaitLargerThanCapacityReturns guards against the Wait spinning
+// forever on any request bigger than one second's worth of tokens -- the bug
+// fixed alongside this test.
+func TestBucketWaitLargerThanCapacityReturns(t *testing.T) {
+	b := NewBucket(1024 * 1024) // 1MB/s, capacity = 1MB
+
+	done := make(chan time.Duration, 1)
+	start := time.Now()
+	go func() {
+		b.Wait(5 * 1024 * 1024) // 5MB request against a 1MB/s bucket
+		done <- time.Since(start)
+	}()
+
+	select {
+	case elapsed := <-done:
+		if elapsed < 3*time.Second || elapsed > 6*time.Second {
+			t.Errorf("Wait(5MB) on a 1MB/s bucket took %v, want ~4s", elapsed)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Wait hung on a request larger than bucket capacity")
+	}
+}
+
+// TestBucketWaitAccumulatesDebt verifies that overdrawing the bucket leaves
+// its tokens negative rather than clamped at zero, so the deficit is still
+// reflected in the next call's wait calculation.
+func TestBucketWaitAccumulatesDebt(t *testing.T) {
+	b := NewBucket(1024 * 1024) // 1MB/s, capacity = 1MB
+	b.Wait(2 * 1024 * 1024)     // overdraws capacity by exactly 1MB
+
+	if b.tokens >= 0 {
+		t.Errorf("tokens = %f after overdrawing, want negative (debt retained)", b.tokens)
+	}
+}
@@ -5,43 +5,55 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"mime"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/cheggaaa/pb/v3"
 	"github.com/spf13/cobra"
+
+	"github.com/ReelTubeVR/reeltube-cli/internal/mediatypes"
+	"github.com/ReelTubeVR/reeltube-cli/internal/ratelimit"
 )
 
 const (
 	defaultConcurrency = 5 // Default number of concurrent uploads
+	maxPartRetries     = 5
+	retryBaseDelay     = 500 * time.Millisecond
+	retryMaxDelay      = 30 * time.Second
 )
 
 func init() {
 	rootCmd.AddCommand(uploadCmd)
+	uploadCmd.Flags().String("file", "", "Path to the source file (required)")
+	uploadCmd.Flags().String("name", "", "Upload name (defaults to the file's base name)")
+	uploadCmd.Flags().Bool("resume", false, "Resume from an existing checkpoint next to the file, if present")
+	uploadCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 0, "Max concurrent part uploads (default: number of CPU cores, or 5 on a single-core machine)")
+	uploadCmd.PersistentFlags().String("max-bandwidth", "", "Maximum aggregate upload bandwidth, e.g. 5MB (bytes/sec)")
+	uploadCmd.PersistentFlags().BoolVar(&adaptive, "adaptive", false, "Adaptively scale concurrency based on observed throughput and error rate")
+	uploadCmd.AddCommand(uploadResumeCmd)
 }
 
 var (
-	concurrency int
+	concurrency       int
+	adaptive          bool
+	maxBandwidthBytes int64
 )
 
 var uploadCmd = &cobra.Command{
 	Use:   "upload",
 	Short: "Upload a media file to ReelTube",
 	Run: func(cmd *cobra.Command, args []string) {
-		// Allowed file extensions and MIME types for video and photo files
-		var allowedExtensions = map[string]bool{
-			".jpg": true, ".jpeg": true, ".png": true, ".gif": true,
-			".mp4": true, ".mov": true, ".avi": true, ".mkv": true,
-		}
-		var allowedMIMEs = map[string]bool{
-			"image/jpeg": true, "image/png": true, "image/gif": true,
-			"video/mp4": true, "video/quicktime": true, "video/x-msvideo": true, "video/x-matroska": true,
+		if err := setMaxBandwidthFromFlag(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 
 		filePath, _ := cmd.Flags().GetString("file")
@@ -67,11 +79,24 @@ var uploadCmd = &cobra.Command{
 
 		// Check the file extension
 		ext := strings.ToLower(filepath.Ext(absPath))
-		if !allowedExtensions[ext] {
+		if !mediatypes.AllowedExtensions[ext] {
 			fmt.Fprintf(os.Stderr, "Error: file type not allowed: %s\n", ext)
 			os.Exit(1)
 		}
 
+		// Hash the file for content-addressed dedup lookup in the background
+		// while the MIME sniff below runs. The hash and the sniff each open
+		// the file on their own, so its first 512 bytes are read twice.
+		type hashResult struct {
+			hash string
+			err  error
+		}
+		hashChan := make(chan hashResult, 1)
+		go func() {
+			hash, err := fileSHA256(absPath)
+			hashChan <- hashResult{hash, err}
+		}()
+
 		// Open the file to check the MIME type
 		file, err := os.Open(absPath)
 		if err != nil {
@@ -91,7 +116,7 @@ var uploadCmd = &cobra.Command{
 		if mimeType == "" {
 			mimeType = http.DetectContentType(buffer)
 		}
-		if !allowedMIMEs[mimeType] {
+		if !mediatypes.AllowedMIMEs[mimeType] {
 			fmt.Fprintf(os.Stderr, "Error: MIME type not allowed: %s\n", mimeType)
 			os.Exit(1)
 		}
@@ -105,11 +130,67 @@ var uploadCmd = &cobra.Command{
 
 		// Logic to handle file upload to ReelTube
 
-		err = multipartUpload(absPath, uploadName)
+		resume, _ := cmd.Flags().GetBool("resume")
+		cpPath := checkpointPath(absPath)
+
+		if resume {
+			if _, err := os.Stat(cpPath); err == nil {
+				if err := resumeMultipartUpload(cpPath); err != nil {
+					fmt.Println("Error uploading file:", err)
+					os.Exit(1)
+				}
+				fmt.Println("File uploaded successfully")
+				return
+			}
+		}
+
+		hr := <-hashChan
+		if hr.err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", hr.err)
+			os.Exit(1)
+		}
+		sha256Hex := hr.hash
+
+		lookup, err := client.LookupMediaByHash(sha256Hex)
 		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if lookup.Exists {
+			media, err := client.CreateMediaFromHash(uploadName, sha256Hex, MediaMetadata{})
+			if err != nil {
+				fmt.Println("Error uploading file:", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "oid: %s\n", sha256Hex)
+			fmt.Printf("File already uploaded, reused existing blob (media id: %s)\n", media.ID)
+			return
+		}
+
+		if err := startMultipartUpload(absPath, uploadName, sha256Hex, MediaMetadata{}); err != nil {
 			fmt.Println("Error uploading file:", err)
 			os.Exit(1)
 		}
+		fmt.Fprintf(os.Stderr, "oid: %s\n", sha256Hex)
+		fmt.Println("File uploaded successfully")
+	},
+}
+
+var uploadResumeCmd = &cobra.Command{
+	Use:   "resume <checkpoint>",
+	Short: "Resume a multipart upload from a checkpoint file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setMaxBandwidthFromFlag(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := resumeMultipartUpload(args[0]); err != nil {
+			fmt.Println("Error resuming upload:", err)
+			os.Exit(1)
+		}
 		fmt.Println("File uploaded successfully")
 	},
 }
@@ -127,136 +208,360 @@ func systemConcurrency() int {
 	return defaultConcurrency
 }
 
-func multipartUpload(filePath, fileName string) error {
+func multipartUpload(filePath, fileName string, resume bool) error {
+	cpPath := checkpointPath(filePath)
+
+	if resume {
+		if _, err := os.Stat(cpPath); err == nil {
+			return resumeMultipartUpload(cpPath)
+		}
+	}
+
+	sha256Hex, err := fileSHA256(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to hash file: %w", err)
+	}
+
+	return startMultipartUpload(filePath, fileName, sha256Hex, MediaMetadata{})
+}
+
+// startMultipartUpload reserves a media upload for a known sha256Hex digest
+// (computed by the caller, so the file is never hashed twice in the same
+// run) and uploads every part before completing it.
+func startMultipartUpload(filePath, fileName, sha256Hex string, meta MediaMetadata) error {
+	cpPath := checkpointPath(filePath)
+
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to get file info: %w", err)
 	}
 
-	fileSize := fileInfo.Size()
-	concurrency := systemConcurrency()
-
 	// Step 1: Get presigned URLs from API
-	createMediaUploadResp, err := createMediaUpload(fileName, int(fileSize))
+	createMediaUploadResp, err := createMediaUpload(fileName, int(fileInfo.Size()), sha256Hex, meta)
 	if err != nil {
 		return fmt.Errorf("failed to get presigned URLs: %w", err)
 	}
 
-	mediaUpload := createMediaUploadResp.MediaUpload
-	partSize := createMediaUploadResp.PartSize
-	numParts := createMediaUploadResp.NumParts
-	uploadID := createMediaUploadResp.UploadID
-	presignedURLs := createMediaUploadResp.PresignedURLs
+	cp := &UploadCheckpoint{
+		FilePath:      filePath,
+		FileName:      fileName,
+		FileSHA256:    sha256Hex,
+		MediaUploadID: createMediaUploadResp.MediaUpload.ID,
+		UploadID:      createMediaUploadResp.UploadID,
+		PartSize:      createMediaUploadResp.PartSize,
+		NumParts:      createMediaUploadResp.NumParts,
+		PresignedURLs: createMediaUploadResp.PresignedURLs,
+		Parts:         make(map[int]Part),
+	}
+
+	if err := saveCheckpoint(cpPath, cp); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	return uploadPartsAndComplete(cp, cpPath)
+}
 
-	// Step 2: Upload each part using the presigned URLs with a worker pool
-	var wg sync.WaitGroup
-	errChan := make(chan error, len(presignedURLs))
-	parts := make([]Part, len(presignedURLs))
+// resumeMultipartUpload reloads a checkpoint, verifies the source file still
+// matches, and uploads any parts that were not already completed before
+// calling CompleteMultipartUpload.
+func resumeMultipartUpload(cpPath string) error {
+	cp, err := loadCheckpoint(cpPath)
+	if err != nil {
+		return err
+	}
 
-	jobs := make(chan int, numParts)
+	if _, err := os.Stat(cp.FilePath); err != nil {
+		return fmt.Errorf("source file for checkpoint not found: %w", err)
+	}
 
-	// Initialize progress bar
-	bar := pb.StartNew(numParts)
-	startTime := time.Now()
+	sha256Hex, err := fileSHA256(cp.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to hash file: %w", err)
+	}
+	if sha256Hex != cp.FileSHA256 {
+		return fmt.Errorf("source file has changed since checkpoint was written (sha256 mismatch)")
+	}
 
-	// Worker pool
-	for w := 0; w < concurrency; w++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for partNum := range jobs {
-				url := presignedURLs[partNum]
-				partOffset := partNum * partSize
-				buffer := make([]byte, partSize)
+	return uploadPartsAndComplete(cp, cpPath)
+}
 
-				// Open a new file descriptor for each goroutine
-				file, err := os.Open(filePath)
-				if err != nil {
-					errChan <- fmt.Errorf("failed to open file: %w", err)
-					return
-				}
+// uploadPartsAndComplete uploads every part of cp not already marked complete,
+// persisting the checkpoint after each part succeeds, then finalizes the
+// upload and deletes the checkpoint on success. It drives its own progress
+// bar; callers that need to share a bar across multiple concurrent uploads
+// (e.g. upload-batch) should use uploadPartsWithBar directly instead.
+func uploadPartsAndComplete(cp *UploadCheckpoint, cpPath string) error {
+	remaining := pendingPartCount(cp)
+	bar := pb.StartNew(remaining)
 
-				_, err = file.Seek(int64(partOffset), io.SeekStart)
-				if err != nil {
-					file.Close()
-					errChan <- fmt.Errorf("failed to seek file for part %d: %w", partNum, err)
-					return
-				}
+	err := uploadPartsWithBar(cp, cpPath, bar)
 
-				// Adjust read size for the last part
-				readSize := partSize
-				if partOffset+partSize > int(fileSize) {
-					readSize = int(fileSize) - partOffset
-				}
+	bar.Finish()
+	if err != nil {
+		return err
+	}
 
-				n, err := file.Read(buffer[:readSize])
-				if err != nil && err != io.EOF {
-					file.Close()
-					errChan <- fmt.Errorf("failed to read file for part %d: %w", partNum, err)
-					return
-				}
-				file.Close()
+	return finalizeUpload(cp, cpPath)
+}
 
-				req, err := http.NewRequest("PUT", url, bytes.NewReader(buffer[:n]))
-				if err != nil {
-					errChan <- fmt.Errorf("failed to create PUT request for part %d: %w", partNum, err)
-					return
-				}
+// pendingPartCount returns how many parts of cp have not yet been uploaded.
+func pendingPartCount(cp *UploadCheckpoint) int {
+	n := 0
+	for i := 0; i < cp.NumParts; i++ {
+		if _, ok := cp.Parts[i]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// uploadPartsWithBar uploads every part of cp not already marked complete,
+// persisting the checkpoint after each part succeeds, and reporting progress
+// on bar as it goes. It does not finalize the upload -- callers are
+// responsible for calling finalizeUpload once uploadPartsWithBar returns nil,
+// which lets upload-batch finish one file's bar independently of another's.
+func uploadPartsWithBar(cp *UploadCheckpoint, cpPath string, bar *pb.ProgressBar) error {
+	initialConcurrency := concurrency
+	if initialConcurrency <= 0 {
+		initialConcurrency = systemConcurrency()
+	}
+
+	var pending []int
+	for i := 0; i < cp.NumParts; i++ {
+		if _, ok := cp.Parts[i]; !ok {
+			pending = append(pending, i)
+		}
+	}
+	remaining := len(pending)
+
+	// Draw from the process-wide sharedBucket rather than building a fresh
+	// one here, so --max-bandwidth bounds aggregate throughput across every
+	// file upload-batch has running concurrently, not just this file's
+	// workers.
+	bucket := sharedBucket
+	limiter := newAdaptiveLimiter(initialConcurrency)
+	throughput := &throughputTracker{}
+
+	// Size the worker pool to the largest the limiter could ever grow to
+	// (when adaptive) so --adaptive can scale active concurrency up without
+	// spawning a fresh goroutine per part -- a multi-GB file can have
+	// thousands of parts, and limiter.acquire()/release() already bounds how
+	// many of these workers are doing real work at once.
+	numWorkers := initialConcurrency
+	if adaptive && numWorkers < maxAdaptiveConcurrency {
+		numWorkers = maxAdaptiveConcurrency
+	}
+	if numWorkers > remaining {
+		numWorkers = remaining
+	}
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		errChan   = make(chan error, cp.NumParts)
+		jobs      = make(chan int)
+		startTime = time.Now()
+		done      int
+	)
+
+	go func() {
+		for _, partNum := range pending {
+			jobs <- partNum
+		}
+		close(jobs)
+	}()
+
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for partNum := range jobs {
+				limiter.acquire()
+				partStart := time.Now()
+				part, n, err := uploadPartWithRetry(cp, partNum, bucket)
+				limiter.release()
 
-				resp, err := http.DefaultClient.Do(req)
 				if err != nil {
-					errChan <- fmt.Errorf("failed to upload part %d: %w", partNum, err)
-					return
+					if adaptive {
+						limiter.reportError()
+					}
+					errChan <- err
+					continue
 				}
-				resp.Body.Close()
-
-				if resp.StatusCode != http.StatusOK {
-					errChan <- fmt.Errorf("failed to upload part %d: received non-200 status code %d", partNum, resp.StatusCode)
-					return
+				if adaptive {
+					limiter.reportSuccess()
 				}
-
-				parts[partNum] = Part{
-					PartNumber: partNum + 1,
-					ETag:       json.RawMessage(resp.Header.Get("ETag")),
+				throughput.update(n, time.Since(partStart).Seconds())
+
+				mu.Lock()
+				cp.Parts[partNum] = part
+				done++
+				if err := saveCheckpoint(cpPath, cp); err != nil {
+					mu.Unlock()
+					errChan <- fmt.Errorf("failed to persist checkpoint after part %d: %w", partNum, err)
+					continue
 				}
+				// Computed while still holding mu: done is shared state
+				// every worker mutates, so reading it for the ETA after
+				// unlocking would race.
+				elapsed := time.Since(startTime)
+				eta := time.Duration(float64(remaining-done)*elapsed.Seconds()/float64(done)) * time.Second
+				mu.Unlock()
 
-				// Update progress bar
 				bar.Increment()
-				elapsed := time.Since(startTime)
-				remaining := time.Duration((numParts-partNum-1)*int(elapsed.Seconds()/float64(partNum+1))) * time.Second
-				bar.Set("remaining", fmt.Sprintf("ETA: %s", remaining))
+				bar.Set("remaining", fmt.Sprintf("ETA: %s | %.1f MB/s | concurrency: %d", eta, throughput.value()/(1024*1024), limiter.current()))
 			}
 		}()
 	}
 
-	// Send jobs to the worker pool
-	for i := 0; i < numParts; i++ {
-		jobs <- i
-	}
-	close(jobs)
-
 	wg.Wait()
 	close(errChan)
 
-	bar.Finish()
-
 	for err := range errChan {
 		if err != nil {
 			return err
 		}
 	}
 
-	// Step 3: Complete multipart upload
-	err = completeMultipartUpload(mediaUpload.ID, uploadID, parts)
-	if err != nil {
+	return nil
+}
+
+// finalizeUpload completes the multipart upload once every part is present
+// in cp.Parts and deletes the on-disk checkpoint.
+func finalizeUpload(cp *UploadCheckpoint, cpPath string) error {
+	parts := make([]Part, cp.NumParts)
+	for i := 0; i < cp.NumParts; i++ {
+		parts[i] = cp.Parts[i]
+	}
+
+	if err := completeMultipartUpload(cp.MediaUploadID, cp.UploadID, parts); err != nil {
 		return fmt.Errorf("failed to complete multipart upload: %w", err)
 	}
 
+	if err := deleteCheckpoint(cpPath); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func createMediaUpload(fileName string, fileSize int) (*CreateMediaUploadResponse, error) {
-	presignedURLResp, err := client.CreateMediaUpload(fileName, fileSize)
+// uploadPartWithRetry reads a single part from disk and PUTs it to its
+// presigned URL, retrying with exponential backoff and jitter on 5xx
+// responses and network errors, and honoring Retry-After on 429.
+func uploadPartWithRetry(cp *UploadCheckpoint, partNum int, bucket *ratelimit.Bucket) (Part, int, error) {
+	url := cp.PresignedURLs[partNum]
+	partOffset := partNum * cp.PartSize
+
+	readSize := cp.PartSize
+	if fileInfo, err := os.Stat(cp.FilePath); err == nil {
+		if partOffset+cp.PartSize > int(fileInfo.Size()) {
+			readSize = int(fileInfo.Size()) - partOffset
+		}
+	}
+
+	buffer := make([]byte, readSize)
+
+	file, err := os.Open(cp.FilePath)
+	if err != nil {
+		return Part{}, 0, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(int64(partOffset), io.SeekStart); err != nil {
+		return Part{}, 0, fmt.Errorf("failed to seek file for part %d: %w", partNum, err)
+	}
+
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return Part{}, 0, fmt.Errorf("failed to read file for part %d: %w", partNum, err)
+	}
+
+	part, err := putPartWithRetry(url, buffer[:n], partNum, bucket)
+	return part, n, err
+}
+
+// putPartWithRetry PUTs a single part's bytes to its presigned URL, retrying
+// with exponential backoff and jitter on 5xx responses and network errors,
+// and honoring Retry-After on 429. If bucket is non-nil, it waits for
+// len(data) tokens before every attempt to stay under the configured
+// aggregate bandwidth limit.
+func putPartWithRetry(url string, data []byte, partNum int, bucket *ratelimit.Bucket) (Part, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxPartRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryDelay(attempt, lastErr))
+		}
+
+		bucket.Wait(len(data))
+
+		req, err := http.NewRequest("PUT", url, bytes.NewReader(data))
+		if err != nil {
+			return Part{}, fmt.Errorf("failed to create PUT request for part %d: %w", partNum, err)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to upload part %d: %w", partNum, err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			etag := resp.Header.Get("ETag")
+			resp.Body.Close()
+			return Part{PartNumber: partNum + 1, ETag: json.RawMessage(etag)}, nil
+		}
+
+		retryAfter := resp.Header.Get("Retry-After")
+		statusCode := resp.StatusCode
+		resp.Body.Close()
+
+		if statusCode == http.StatusTooManyRequests || statusCode >= 500 {
+			lastErr = retryableStatusError{statusCode: statusCode, retryAfter: retryAfter, partNum: partNum}
+			continue
+		}
+
+		return Part{}, fmt.Errorf("failed to upload part %d: received non-200 status code %d", partNum, statusCode)
+	}
+
+	return Part{}, fmt.Errorf("failed to upload part %d after %d attempts: %w", partNum, maxPartRetries+1, lastErr)
+}
+
+// retryableStatusError carries enough context from a failed PUT for
+// retryDelay to honor a server-supplied Retry-After.
+type retryableStatusError struct {
+	statusCode int
+	retryAfter string
+	partNum    int
+}
+
+func (e retryableStatusError) Error() string {
+	return fmt.Sprintf("part %d: received status code %d", e.partNum, e.statusCode)
+}
+
+// retryDelay computes the backoff before the next retry attempt. If lastErr
+// carries a Retry-After header (429), that takes precedence; otherwise it
+// falls back to exponential backoff with jitter, capped at retryMaxDelay.
+func retryDelay(attempt int, lastErr error) time.Duration {
+	if rse, ok := lastErr.(retryableStatusError); ok && rse.statusCode == http.StatusTooManyRequests && rse.retryAfter != "" {
+		if secs, err := strconv.Atoi(rse.retryAfter); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	delay := retryBaseDelay * time.Duration(1<<uint(attempt-1))
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay + jitter
+}
+
+func createMediaUpload(fileName string, fileSize int, sha256Hex string, meta MediaMetadata) (*CreateMediaUploadResponse, error) {
+	presignedURLResp, err := client.CreateMediaUpload(fileName, fileSize, sha256Hex, meta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get presigned URLs from API: %w", err)
 	}
@@ -272,3 +577,108 @@ func completeMultipartUpload(mediaUploadId, uploadID string, parts []Part) error
 
 	return nil
 }
+
+const defaultStreamPartSize = 8 * 1024 * 1024 // used when the server doesn't report a part size for streaming uploads
+
+// streamUpload negotiates a streaming (unknown-size) upload via --stream
+// instead of learning the true size up front.
+var streamUpload bool
+
+// multipartUploadFromReader uploads the contents of reader, which has no
+// seekable backing file (e.g. a live-fetched remote or YouTube stream).
+// sizeHint is the content length reported by the source, if any, and is
+// used only for progress reporting since CreateMediaUpload needs an exact
+// size. Unless streamUpload is set, the reader is first spooled to a temp
+// file on disk so its true size is known, then uploaded via the normal
+// multipartUpload path (checkpointing, retries, and all).
+func multipartUploadFromReader(reader io.Reader, sizeHint int64, fileName string) error {
+	if streamUpload {
+		return streamingMultipartUpload(reader, fileName)
+	}
+
+	if sizeHint > 0 {
+		fmt.Printf("Buffering %d bytes to disk to determine upload size...\n", sizeHint)
+	} else {
+		fmt.Println("Buffering stream to disk to determine upload size...")
+	}
+
+	tempFile, err := os.CreateTemp("", "reeltube-ingest-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	// The checkpoint multipartUpload writes for tempPath is useless once
+	// this function returns -- the backing temp file is gone either way, so
+	// there's nothing for "upload resume"/--resume to resume. Remove both
+	// together instead of leaving a checkpoint that points at a deleted file.
+	defer func() {
+		os.Remove(tempPath)
+		deleteCheckpoint(checkpointPath(tempPath))
+	}()
+
+	if _, err := io.Copy(tempFile, reader); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to buffer stream to disk: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to finalize temp file: %w", err)
+	}
+
+	return multipartUpload(tempPath, fileName, false)
+}
+
+// streamingMultipartUpload uploads reader part-by-part as bytes become
+// available, never learning (or requiring) the total size up front. Each
+// part's presigned URL is negotiated individually via GetPresignedPartURL
+// since the server can't hand out a full URL list without a known part
+// count.
+func streamingMultipartUpload(reader io.Reader, fileName string) error {
+	createResp, err := client.CreateStreamingMediaUpload(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to create streaming media upload: %w", err)
+	}
+
+	partSize := createResp.PartSize
+	if partSize <= 0 {
+		partSize = defaultStreamPartSize
+	}
+
+	mediaUpload := createResp.MediaUpload
+	uploadID := createResp.UploadID
+	bucket := sharedBucket
+
+	var parts []Part
+	buffer := make([]byte, partSize)
+
+	for partNum := 0; ; partNum++ {
+		n, readErr := io.ReadFull(reader, buffer)
+		if readErr != nil && readErr != io.ErrUnexpectedEOF && readErr != io.EOF {
+			return fmt.Errorf("failed to read stream for part %d: %w", partNum+1, readErr)
+		}
+		if n == 0 {
+			break
+		}
+
+		url, err := client.GetPresignedPartURL(mediaUpload.ID, uploadID, partNum+1)
+		if err != nil {
+			return fmt.Errorf("failed to get presigned URL for part %d: %w", partNum+1, err)
+		}
+
+		part, err := putPartWithRetry(url, buffer[:n], partNum, bucket)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+		fmt.Printf("Uploaded part %d (%d bytes)\n", partNum+1, n)
+
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+	}
+
+	if err := completeMultipartUpload(mediaUpload.ID, uploadID, parts); err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+
+	return nil
+}
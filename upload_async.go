@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	uploadCmd.AddCommand(uploadCreateCmd)
+	uploadCmd.AddCommand(uploadPushCmd)
+	uploadCmd.AddCommand(uploadCompleteCmd)
+	uploadCmd.AddCommand(uploadStatusCmd)
+
+	uploadCreateCmd.Flags().String("file", "", "Path to the source file (required)")
+	uploadCreateCmd.Flags().String("name", "", "Upload name (defaults to the file's base name)")
+	uploadCreateCmd.Flags().String("state", "", "Where to write the upload state file (defaults to <file>.reeltube-upload.json)")
+
+	uploadPushCmd.Flags().String("state", "", "Path to the upload state file (required)")
+	uploadPushCmd.Flags().String("parts", "", "Comma-separated 1-based part numbers to push (default: all incomplete parts)")
+	uploadPushCmd.Flags().String("max-bandwidth", "", "Maximum aggregate upload bandwidth, e.g. 5MB (bytes/sec)")
+
+	uploadCompleteCmd.Flags().String("state", "", "Path to the upload state file (required)")
+}
+
+// uploadCreateCmd, uploadPushCmd, and uploadCompleteCmd split multipartUpload's
+// three phases into independently callable steps, driven by a shared state
+// file (the same UploadCheckpoint format the "upload" and "upload resume"
+// commands use), so CI systems can reserve a slot, fan part uploads out
+// across runners, and retry finalize separately.
+var uploadCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Reserve a multipart upload slot and write its state to disk",
+	Run: func(cmd *cobra.Command, args []string) {
+		filePath, _ := cmd.Flags().GetString("file")
+		name, _ := cmd.Flags().GetString("name")
+		statePath, _ := cmd.Flags().GetString("state")
+
+		if filePath == "" {
+			fmt.Fprintln(os.Stderr, "Error: --file is required")
+			os.Exit(1)
+		}
+
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid file path: %v\n", err)
+			os.Exit(1)
+		}
+
+		if name == "" {
+			name = filepath.Base(absPath)
+		}
+		if statePath == "" {
+			statePath = checkpointPath(absPath)
+		}
+
+		fileInfo, err := os.Stat(absPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: unable to stat file: %v\n", err)
+			os.Exit(1)
+		}
+
+		sha256Hex, err := fileSHA256(absPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		resp, err := createMediaUpload(name, int(fileInfo.Size()), sha256Hex, MediaMetadata{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		cp := &UploadCheckpoint{
+			FilePath:      absPath,
+			FileName:      name,
+			FileSHA256:    sha256Hex,
+			MediaUploadID: resp.MediaUpload.ID,
+			UploadID:      resp.UploadID,
+			PartSize:      resp.PartSize,
+			NumParts:      resp.NumParts,
+			PresignedURLs: resp.PresignedURLs,
+			Parts:         make(map[int]Part),
+		}
+
+		if err := saveCheckpoint(statePath, cp); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"media_upload":   cp.MediaUploadID,
+			"upload_id":      cp.UploadID,
+			"part_size":      cp.PartSize,
+			"num_parts":      cp.NumParts,
+			"presigned_urls": cp.PresignedURLs,
+			"state_file":     statePath,
+		}, "", "  ")
+		fmt.Println(string(out))
+	},
+}
+
+var uploadPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Upload (or re-upload) a subset of parts from a saved upload state",
+	Run: func(cmd *cobra.Command, args []string) {
+		statePath, _ := cmd.Flags().GetString("state")
+		partsFlag, _ := cmd.Flags().GetString("parts")
+
+		if statePath == "" {
+			fmt.Fprintln(os.Stderr, "Error: --state is required")
+			os.Exit(1)
+		}
+
+		if err := setMaxBandwidthFromFlag(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		bucket := sharedBucket
+
+		cp, err := loadCheckpoint(statePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		partIndexes, err := resolvePushPartIndexes(cp, partsFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, partNum := range partIndexes {
+			part, _, err := uploadPartWithRetry(cp, partNum, bucket)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			cp.Parts[partNum] = part
+			if err := saveCheckpoint(statePath, cp); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Pushed part %d/%d\n", partNum+1, cp.NumParts)
+		}
+	},
+}
+
+// resolvePushPartIndexes parses --parts (a comma-separated list of 1-based
+// part numbers) into 0-based part indexes, defaulting to every part that
+// hasn't completed yet when --parts is omitted.
+func resolvePushPartIndexes(cp *UploadCheckpoint, partsFlag string) ([]int, error) {
+	if partsFlag == "" {
+		var indexes []int
+		for i := 0; i < cp.NumParts; i++ {
+			if _, ok := cp.Parts[i]; !ok {
+				indexes = append(indexes, i)
+			}
+		}
+		return indexes, nil
+	}
+
+	var indexes []int
+	for _, raw := range strings.Split(partsFlag, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --parts value %q: %w", raw, err)
+		}
+		if n < 1 || n > cp.NumParts {
+			return nil, fmt.Errorf("part %d is out of range (upload has %d parts)", n, cp.NumParts)
+		}
+		indexes = append(indexes, n-1)
+	}
+	return indexes, nil
+}
+
+var uploadCompleteCmd = &cobra.Command{
+	Use:   "complete",
+	Short: "Finalize a multipart upload from a saved upload state",
+	Run: func(cmd *cobra.Command, args []string) {
+		statePath, _ := cmd.Flags().GetString("state")
+		if statePath == "" {
+			fmt.Fprintln(os.Stderr, "Error: --state is required")
+			os.Exit(1)
+		}
+
+		cp, err := loadCheckpoint(statePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var missing []int
+		parts := make([]Part, cp.NumParts)
+		for i := 0; i < cp.NumParts; i++ {
+			part, ok := cp.Parts[i]
+			if !ok {
+				missing = append(missing, i+1)
+				continue
+			}
+			parts[i] = part
+		}
+		if len(missing) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: missing parts %v -- push them first with 'upload push'\n", missing)
+			os.Exit(1)
+		}
+
+		if err := completeMultipartUpload(cp.MediaUploadID, cp.UploadID, parts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := deleteCheckpoint(statePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Upload complete:", cp.MediaUploadID)
+	},
+}
+
+var uploadStatusCmd = &cobra.Command{
+	Use:   "status <media_upload_id>",
+	Short: "Poll the server for a media upload's current status",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		status, err := client.GetMediaUploadStatus(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		out, _ := json.MarshalIndent(status, "", "  ")
+		fmt.Println(string(out))
+	},
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ReelTubeVR/reeltube-cli/internal/mediatypes"
+)
+
+func init() {
+	rootCmd.AddCommand(uploadBatchCmd)
+	uploadBatchCmd.Flags().Bool("recursive", false, "Recurse into subdirectories")
+	uploadBatchCmd.Flags().String("manifest", "", "YAML or JSON file overriding name/tags/visibility per file path")
+	uploadBatchCmd.Flags().String("report", "", "Where to write the post-run report manifest (default: <dir-or-glob>.reeltube-batch-report.json)")
+	uploadBatchCmd.Flags().String("only-failed", "", "Re-run only the files marked failed in a previous report file")
+	uploadBatchCmd.Flags().Int("file-concurrency", 2, "Number of files to upload in parallel")
+	uploadBatchCmd.Flags().IntVar(&concurrency, "part-concurrency", 0, "Max concurrent part uploads per file (default: number of CPU cores, or 5 on a single-core machine)")
+	uploadBatchCmd.Flags().String("max-bandwidth", "", "Maximum aggregate upload bandwidth across every file, e.g. 5MB (bytes/sec)")
+	uploadBatchCmd.Flags().BoolVar(&adaptive, "adaptive", false, "Adaptively scale each file's part concurrency based on observed throughput and error rate")
+}
+
+// batchManifestEntry overrides per-file upload metadata in a --manifest
+// file. Keys in the manifest are matched against each walked file's path
+// relative to the batch root.
+type batchManifestEntry struct {
+	Name       string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Tags       []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Visibility string   `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+}
+
+// batchReportEntry records the outcome of uploading a single file from an
+// upload-batch run. The report file this is written to can be replayed with
+// --only-failed to retry just the files that didn't make it.
+type batchReportEntry struct {
+	Path          string `json:"path"`
+	MediaUploadID string `json:"media_upload_id,omitempty"`
+	SHA256        string `json:"sha256,omitempty"`
+	Bytes         int64  `json:"bytes"`
+	Status        string `json:"status"` // "uploaded", "deduped", or "failed"
+	Error         string `json:"error,omitempty"`
+}
+
+var uploadBatchCmd = &cobra.Command{
+	Use:   "upload-batch <dir-or-glob>",
+	Short: "Upload every matching media file under a directory (or glob) to ReelTube",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		root := args[0]
+
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		manifestPath, _ := cmd.Flags().GetString("manifest")
+		reportPath, _ := cmd.Flags().GetString("report")
+		onlyFailedPath, _ := cmd.Flags().GetString("only-failed")
+		fileConcurrency, _ := cmd.Flags().GetInt("file-concurrency")
+
+		if err := setMaxBandwidthFromFlag(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if fileConcurrency < 1 {
+			fileConcurrency = 1
+		}
+		if reportPath == "" {
+			reportPath = strings.TrimRight(root, string(filepath.Separator)) + ".reeltube-batch-report.json"
+		}
+
+		files, err := walkBatchFiles(root, recursive)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		manifest, err := loadBatchManifest(manifestPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if onlyFailedPath != "" {
+			files, err = filterToFailed(files, onlyFailedPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if len(files) == 0 {
+			fmt.Println("No matching files to upload")
+			return
+		}
+
+		report := runBatchUpload(files, root, manifest, fileConcurrency)
+
+		if err := writeBatchReport(reportPath, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write report: %v\n", err)
+			os.Exit(1)
+		}
+
+		uploaded, deduped, failed := 0, 0, 0
+		for _, entry := range report {
+			switch entry.Status {
+			case "uploaded":
+				uploaded++
+			case "deduped":
+				deduped++
+			default:
+				failed++
+			}
+		}
+		fmt.Printf("Batch complete: %d uploaded, %d deduped, %d failed (report: %s)\n", uploaded, deduped, failed, reportPath)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// walkBatchFiles resolves root to a list of files matching the allowed media
+// extensions. root may be a directory (walked non-recursively unless
+// recursive is set) or a glob pattern.
+func walkBatchFiles(root string, recursive bool) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		if matches, globErr := filepath.Glob(root); globErr == nil && len(matches) > 0 {
+			return filterAllowedFiles(matches), nil
+		}
+		return nil, fmt.Errorf("failed to stat %s: %w", root, err)
+	}
+
+	if !info.IsDir() {
+		return filterAllowedFiles([]string{root}), nil
+	}
+
+	var files []string
+	walkFn := func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if !recursive && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	}
+	if err := filepath.WalkDir(root, walkFn); err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	return filterAllowedFiles(files), nil
+}
+
+// filterAllowedFiles keeps only the paths whose extension and sniffed MIME
+// type are in mediatypes' allow lists, matching the checks "upload" applies
+// to a single file.
+func filterAllowedFiles(paths []string) []string {
+	var kept []string
+	for _, path := range paths {
+		ext := strings.ToLower(filepath.Ext(path))
+		if !mediatypes.AllowedExtensions[ext] {
+			continue
+		}
+
+		mimeType := mime.TypeByExtension(ext)
+		if mimeType == "" {
+			buffer := make([]byte, 512)
+			file, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			n, _ := file.Read(buffer)
+			file.Close()
+			mimeType = http.DetectContentType(buffer[:n])
+		}
+		if !mediatypes.AllowedMIMEs[mimeType] {
+			continue
+		}
+
+		kept = append(kept, path)
+	}
+	return kept
+}
+
+// loadBatchManifest parses a --manifest file (YAML or JSON, chosen by
+// extension) into a map keyed by the file paths it overrides. A blank
+// manifestPath returns an empty map.
+func loadBatchManifest(manifestPath string) (map[string]batchManifestEntry, error) {
+	manifest := make(map[string]batchManifestEntry)
+	if manifestPath == "" {
+		return manifest, nil
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(manifestPath))
+	switch ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+	}
+
+	return manifest, nil
+}
+
+// filterToFailed loads a previous batch report and keeps only the files from
+// files that are present in it and not already "uploaded" or "deduped".
+func filterToFailed(files []string, onlyFailedPath string) ([]string, error) {
+	data, err := os.ReadFile(onlyFailedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous report: %w", err)
+	}
+
+	var previous []batchReportEntry
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, fmt.Errorf("failed to parse previous report: %w", err)
+	}
+
+	failedPaths := make(map[string]bool)
+	for _, entry := range previous {
+		if entry.Status != "uploaded" && entry.Status != "deduped" {
+			failedPaths[entry.Path] = true
+		}
+	}
+
+	var kept []string
+	for _, path := range files {
+		if failedPaths[path] {
+			kept = append(kept, path)
+		}
+	}
+	return kept, nil
+}
+
+// runBatchUpload schedules files across fileConcurrency workers, each
+// driving its own row in a shared progress bar pool, and returns one report
+// entry per file in the order they were scheduled.
+func runBatchUpload(files []string, root string, manifest map[string]batchManifestEntry, fileConcurrency int) []batchReportEntry {
+	if fileConcurrency > len(files) {
+		fileConcurrency = len(files)
+	}
+
+	bars := make([]*pb.ProgressBar, fileConcurrency)
+	for i := range bars {
+		bars[i] = pb.New(0)
+	}
+	pool := pb.NewPool(bars...)
+	if err := pool.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to start progress display: %v\n", err)
+	} else {
+		defer pool.Stop()
+	}
+
+	jobs := make(chan int)
+	reports := make([]batchReportEntry, len(files))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < fileConcurrency; worker++ {
+		wg.Add(1)
+		go func(bar *pb.ProgressBar) {
+			defer wg.Done()
+			for i := range jobs {
+				reports[i] = uploadBatchFile(files[i], root, manifest, bar)
+			}
+		}(bars[worker])
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return reports
+}
+
+// uploadBatchFile uploads a single file as part of a batch run, reusing bar
+// across however many files this worker ends up processing.
+func uploadBatchFile(path, root string, manifest map[string]batchManifestEntry, bar *pb.ProgressBar) batchReportEntry {
+	entry := batchReportEntry{Path: path}
+
+	relPath, err := filepath.Rel(root, path)
+	if err != nil {
+		relPath = path
+	}
+	override, ok := manifest[relPath]
+	if !ok {
+		override = manifest[path]
+	}
+
+	uploadName := filepath.Base(path)
+	if override.Name != "" {
+		uploadName = override.Name
+	}
+	meta := MediaMetadata{Tags: override.Tags, Visibility: override.Visibility}
+
+	bar.Set("prefix", uploadName)
+	bar.SetCurrent(0)
+	bar.SetTotal(1)
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return entry
+	}
+	entry.Bytes = fileInfo.Size()
+
+	sha256Hex, err := fileSHA256(path)
+	if err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return entry
+	}
+	entry.SHA256 = sha256Hex
+
+	lookup, err := client.LookupMediaByHash(sha256Hex)
+	if err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return entry
+	}
+	if lookup.Exists {
+		media, err := client.CreateMediaFromHash(uploadName, sha256Hex, meta)
+		if err != nil {
+			entry.Status = "failed"
+			entry.Error = err.Error()
+			return entry
+		}
+		bar.SetCurrent(1)
+		entry.MediaUploadID = media.ID
+		entry.Status = "deduped"
+		return entry
+	}
+
+	cpPath := checkpointPath(path)
+	resp, err := createMediaUpload(uploadName, int(fileInfo.Size()), sha256Hex, meta)
+	if err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return entry
+	}
+
+	cp := &UploadCheckpoint{
+		FilePath:      path,
+		FileName:      uploadName,
+		FileSHA256:    sha256Hex,
+		MediaUploadID: resp.MediaUpload.ID,
+		UploadID:      resp.UploadID,
+		PartSize:      resp.PartSize,
+		NumParts:      resp.NumParts,
+		PresignedURLs: resp.PresignedURLs,
+		Parts:         make(map[int]Part),
+	}
+	if err := saveCheckpoint(cpPath, cp); err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return entry
+	}
+
+	bar.SetTotal(int64(cp.NumParts))
+
+	if err := uploadPartsWithBar(cp, cpPath, bar); err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return entry
+	}
+	if err := finalizeUpload(cp, cpPath); err != nil {
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return entry
+	}
+
+	entry.MediaUploadID = cp.MediaUploadID
+	entry.Status = "uploaded"
+	return entry
+}
+
+func writeBatchReport(reportPath string, report []batchReportEntry) error {
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(reportPath, out, 0644)
+}